@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const depCheckTimeout = 20 * time.Second
+
+// templateModules are the modules the generated code imports from. An import
+// belongs to the longest module path it starts with.
+var templateModules = []string{
+	"github.com/99designs/gqlgen",
+	"github.com/gin-contrib/requestid",
+	"github.com/gin-gonic/gin",
+	"github.com/go-chi/chi/v5",
+	"github.com/go-sql-driver/mysql",
+	"github.com/gofiber/fiber/v2",
+	"github.com/gorilla/securecookie",
+	"github.com/gorilla/sessions",
+	"github.com/labstack/echo/v4",
+	"github.com/redis/go-redis/v9",
+	"github.com/uptrace/bun",
+	"github.com/uptrace/bun/dialect/mysqldialect",
+	"github.com/uptrace/bun/dialect/pgdialect",
+	"github.com/uptrace/bun/dialect/sqlitedialect",
+	"github.com/uptrace/bun/driver/pgdriver",
+	"github.com/uptrace/bun/driver/sqliteshim",
+	"github.com/valyala/fasthttp",
+	"go.mongodb.org/mongo-driver",
+	"golang.org/x/sync",
+	"gorm.io/driver/mysql",
+	"gorm.io/driver/postgres",
+	"gorm.io/driver/sqlite",
+	"gorm.io/gorm",
+}
+
+// dependencyModules renders the project for cfg without writing anything and
+// lists the modules its Go files import, sorted and without duplicates.
+func dependencyModules(cfg ProjectConfig) ([]string, error) {
+	imports, err := plannedImports(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var modules []string
+	for _, path := range imports {
+		if mod, ok := moduleOf(path, cfg.ModulePath()); ok {
+			modules = append(modules, mod)
+		}
+	}
+	slices.Sort(modules)
+	return slices.Compact(modules), nil
+}
+
+// plannedImports does a dry run of the generation into a scratch result and
+// returns the import paths of the Go files it would write.
+func plannedImports(cfg ProjectConfig) ([]string, error) {
+	result, dryRun := generated, *dryRunFlag
+	generated, *dryRunFlag = &GenerationResult{}, true
+	defer func() { generated, *dryRunFlag = result, dryRun }()
+
+	if err := InitProject(cfg, &phaseTimer{}); err != nil {
+		return nil, err
+	}
+	if err := writeProjectFiles(cfg); err != nil {
+		return nil, err
+	}
+	return generated.Imports(), nil
+}
+
+// moduleOf returns the module providing the import path, or false for the
+// standard library and the project's own packages. An import from a module
+// missing from templateModules is returned as is, so checking it fails loudly
+// instead of being skipped.
+func moduleOf(path, projectModule string) (string, bool) {
+	first, _, _ := strings.Cut(path, "/")
+	if !strings.Contains(first, ".") || path == projectModule || strings.HasPrefix(path, projectModule+"/") {
+		return "", false
+	}
+	mod := ""
+	for _, m := range templateModules {
+		if (path == m || strings.HasPrefix(path, m+"/")) && len(m) > len(mod) {
+			mod = m
+		}
+	}
+	if mod == "" {
+		return path, true
+	}
+	return mod, true
+}
+
+// goImports returns the import paths of a Go source file, or none when it
+// doesn't parse.
+func goImports(src string) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, imp := range f.Imports {
+		if path, err := strconv.Unquote(imp.Path.Value); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// checkDependencies resolves each module's latest version through the module
+// proxy, so network or proxy problems show up before any file is written.
+func checkDependencies(modules []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), depCheckTimeout)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, mod := range modules {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			out, err := exec.CommandContext(ctx, "go", "list", "-m", mod+"@latest").CombinedOutput()
+			if err == nil {
+				return
+			}
+			if ctx.Err() != nil {
+				err = fmt.Errorf("timed out after %s", depCheckTimeout)
+			} else if msg := strings.TrimSpace(string(out)); msg != "" {
+				err = errors.New(msg)
+			}
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("%s: %w", mod, err))
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to resolve dependencies:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+var (
+	requiresGoRegex  = regexp.MustCompile(`(\S+@\S+) requires go >= ([0-9][0-9.]*)(?: \(running go ([0-9][0-9.]*))?`)
+	requestedGoRegex = regexp.MustCompile(`(\S+@\S+) requires go@([0-9][0-9.]*), but ([0-9][0-9.]*) is requested`)
+)
+
+// tidyHint turns the go mod tidy failures people hit most into advice, or
+// returns "" when the output isn't recognized.
+func tidyHint(output string) string {
+	if m := requestedGoRegex.FindStringSubmatch(output); m != nil {
+		return fmt.Sprintf("%s requires Go %s, newer than the Go %s picked with --go-version. Pass --go-version %s or later, or leave it out to use your toolchain's version.", m[1], m[2], m[3], m[2])
+	}
+	if m := requiresGoRegex.FindStringSubmatch(output); m != nil {
+		running := ""
+		if m[3] != "" {
+			running = ", but you're running Go " + m[3]
+		}
+		return fmt.Sprintf("%s requires Go %s or newer%s. Install a newer Go, or set GOTOOLCHAIN=auto to let the go command download one. With --go-version, also pick %s or later.", m[1], m[2], running, m[2])
+	}
+	switch {
+	case strings.Contains(output, "retracted"):
+		return "a dependency version was retracted by its authors. Run go get <module>@latest in the project to move to a supported release."
+	case strings.Contains(output, "dial tcp"), strings.Contains(output, "i/o timeout"), strings.Contains(output, "proxyconnect"):
+		return "the module proxy couldn't be reached. Check your network and GOPROXY settings; --check-deps catches this before any file is written."
+	}
+	return ""
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTidyHint(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string // substrings of the hint, none for no hint
+	}{
+		{
+			name:   "requested go version too old",
+			output: "go: github.com/gin-gonic/gin@v1.10.0 requires go@1.23.0, but 1.22.0 is requested",
+			want:   []string{"github.com/gin-gonic/gin@v1.10.0 requires Go 1.23.0", "Go 1.22.0 picked with --go-version", "--go-version 1.23.0 or later"},
+		},
+		{
+			name:   "toolchain too old",
+			output: "go: github.com/labstack/echo/v4@v4.13.0 requires go >= 1.23.0 (running go 1.22.5; GOTOOLCHAIN=local)",
+			want:   []string{"github.com/labstack/echo/v4@v4.13.0 requires Go 1.23.0 or newer, but you're running Go 1.22.5", "GOTOOLCHAIN=auto"},
+		},
+		{
+			name:   "toolchain too old without running version",
+			output: "go: example.com/mod@v1.0.0 requires go >= 1.24",
+			want:   []string{"example.com/mod@v1.0.0 requires Go 1.24 or newer.", "With --go-version, also pick 1.24 or later."},
+		},
+		{
+			name:   "retracted",
+			output: "go: warning: example.com/mod@v1.2.0: retracted by module author",
+			want:   []string{"retracted by its authors"},
+		},
+		{
+			name:   "proxy unreachable",
+			output: `go: example.com/mod@v1.0.0: Get "https://proxy.golang.org/example.com/mod/@v/list": dial tcp: lookup proxy.golang.org: no such host`,
+			want:   []string{"module proxy couldn't be reached"},
+		},
+		{
+			name:   "timeout",
+			output: "go: example.com/mod: net/http: i/o timeout",
+			want:   []string{"module proxy couldn't be reached"},
+		},
+		{
+			name:   "unrecognized",
+			output: "go: updates to go.mod needed; to update it:\n\tgo mod tidy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := tidyHint(tt.output)
+			if len(tt.want) == 0 && hint != "" {
+				t.Fatalf("tidyHint() = %q, want no hint", hint)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(hint, want) {
+					t.Errorf("tidyHint() = %q, want it to contain %q", hint, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDependencyModules(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+		want []string // modules the list must hold
+	}{
+		{
+			name: "bare",
+			cfg:  ProjectConfig{Framework: "chi", Database: "none"},
+			want: []string{"github.com/go-chi/chi/v5"},
+		},
+		{
+			name: "bun on postgresql",
+			cfg:  ProjectConfig{Framework: "gin", Database: "postgresql", ORM: "bun", BunMigrate: true},
+			want: []string{"github.com/uptrace/bun", "github.com/uptrace/bun/dialect/pgdialect", "github.com/uptrace/bun/driver/pgdriver"},
+		},
+		{
+			name: "bun on mysql",
+			cfg:  ProjectConfig{Framework: "echo", Database: "mysql", ORM: "bun"},
+			want: []string{"github.com/uptrace/bun/dialect/mysqldialect", "github.com/go-sql-driver/mysql"},
+		},
+		{
+			name: "bun on sqlite",
+			cfg:  ProjectConfig{Framework: "stdlib", Database: "sqlite", ORM: "bun"},
+			want: []string{"github.com/uptrace/bun/dialect/sqlitedialect", "github.com/uptrace/bun/driver/sqliteshim"},
+		},
+		{
+			name: "profiling",
+			cfg:  ProjectConfig{Framework: "stdlib", Database: "none", Pprof: true},
+			want: []string{"golang.org/x/sync"},
+		},
+		{
+			name: "fiber events",
+			cfg:  ProjectConfig{Framework: "fiber", Database: "none", SSE: true},
+			want: []string{"github.com/gofiber/fiber/v2", "github.com/valyala/fasthttp"},
+		},
+		{
+			name: "redis sessions",
+			cfg:  ProjectConfig{Framework: "chi", Database: "redis", Sessions: "redis", DI: "manual"},
+			want: []string{"github.com/gorilla/securecookie", "github.com/gorilla/sessions", "github.com/redis/go-redis/v9"},
+		},
+		{
+			name: "services",
+			cfg:  ProjectConfig{Framework: "gin", Database: "none", Services: []Service{{Name: "api", Framework: "gin"}, {Name: "web", Framework: "echo"}}},
+			want: []string{"github.com/gin-gonic/gin", "github.com/labstack/echo/v4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dependencyModules(testProjectConfig(tt.cfg))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, mod := range tt.want {
+				if !slices.Contains(got, mod) {
+					t.Errorf("dependencyModules() = %v, want it to contain %s", got, mod)
+				}
+			}
+			if tt.cfg.ORM == "bun" && slices.Contains(got, "gorm.io/gorm") {
+				t.Errorf("dependencyModules() = %v, want no gorm with bun", got)
+			}
+		})
+	}
+}
+
+// TestTemplateModulesCoverImports checks every third-party package the
+// templates import, for each framework and database with the optional
+// features on, belongs to a module in templateModules.
+func TestTemplateModulesCoverImports(t *testing.T) {
+	for _, framework := range frameworks {
+		for _, database := range []string{"postgresql", "mysql", "sqlite", "mongodb", "redis", "none"} {
+			for _, orm := range []string{"gorm", "bun"} {
+				cfg := testProjectConfig(ProjectConfig{
+					Framework:    framework,
+					Database:     database,
+					ORM:          orm,
+					BunMigrate:   true,
+					Logging:      true,
+					LogFormat:    "pretty",
+					GraphQL:      true,
+					HTTPClient:   true,
+					Sessions:     "cookie",
+					SSE:          true,
+					EventBus:     true,
+					FeatureFlags: true,
+					DI:           "manual",
+					Middleware:   []string{"recovery", "request-id", "body-limit"},
+					OpenAPI:      true,
+					Pprof:        true,
+				})
+				if database == "redis" {
+					cfg.Sessions = "redis"
+				}
+				imports, err := plannedImports(cfg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, path := range imports {
+					if mod, ok := moduleOf(path, cfg.ModulePath()); ok && !slices.Contains(templateModules, mod) {
+						t.Errorf("%s/%s/%s: %s is from a module missing from templateModules", framework, database, orm, path)
+					}
+				}
+			}
+		}
+	}
+}
+
+// testProjectConfig fills in what the form would for cfg.
+func testProjectConfig(cfg ProjectConfig) ProjectConfig {
+	cfg.GithubUserID = "user"
+	cfg.ProjectName = "project"
+	cfg.Layout = "hexagonal"
+	cfg.Port = 8080
+	cfg.APIClient = "none"
+	if cfg.Sessions == "" {
+		cfg.Sessions = "none"
+	}
+	if cfg.DI == "" {
+		cfg.DI = "none"
+	}
+	if !cfg.SQLDatabase() {
+		cfg.ORM = ""
+		cfg.BunMigrate = false
+	}
+	return cfg
+}
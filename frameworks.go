@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// frameworkAliases maps the other names people use for a framework, in flags,
+// to its canonical name.
+var frameworkAliases = map[string]string{
+	"std":       "stdlib",
+	"net/http":  "stdlib",
+	"nethttp":   "stdlib",
+	"http":      "stdlib",
+	"gin-gonic": "gin",
+	"labstack":  "echo",
+	"gofiber":   "fiber",
+	"go-chi":    "chi",
+}
+
+// resolveFramework returns the canonical framework name for a flag value,
+// accepting aliases and any letter case. On an unknown name the error
+// suggests the closest framework when the value looks like a typo.
+func resolveFramework(value string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	if slices.Contains(frameworks, name) {
+		return name, nil
+	}
+	if canonical, ok := frameworkAliases[name]; ok {
+		return canonical, nil
+	}
+
+	if suggestion, ok := closestFramework(name); ok {
+		return "", fmt.Errorf("unknown framework %q, did you mean %q?", value, suggestion)
+	}
+	return "", fmt.Errorf("unknown framework %q, expected one of %s", value, strings.Join(frameworks, ", "))
+}
+
+// closestFramework finds the framework or alias nearest to name, if it's
+// within two edits.
+func closestFramework(name string) (string, bool) {
+	const maxDistance = 2
+
+	best, bestDistance := "", maxDistance+1
+	candidates := slices.Clone(frameworks)
+	for alias := range frameworkAliases {
+		candidates = append(candidates, alias)
+	}
+	slices.Sort(candidates)
+
+	for _, candidate := range candidates {
+		if d := editDistance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	if best == "" {
+		return "", false
+	}
+	if canonical, ok := frameworkAliases[best]; ok {
+		best = canonical
+	}
+	return best, true
+}
+
+// editDistance is the number of single-byte insertions, deletions,
+// substitutions and adjacent swaps turning a into b, so "gni" is one edit
+// from "gin".
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"gin", "gin", 0},
+		{"", "chi", 3},
+		{"gin", "", 3},
+		{"gni", "gin", 1},    // adjacent swap
+		{"echoo", "echo", 1}, // deletion
+		{"fibr", "fiber", 1}, // insertion
+		{"chu", "chi", 1},    // substitution
+		{"stdlib", "chi", 5},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestFramework(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "gni", want: "gin", wantOK: true},
+		{name: "ehco", want: "echo", wantOK: true},
+		{name: "stdlb", want: "stdlib", wantOK: true},
+		{name: "gofibre", want: "fiber", wantOK: true}, // alias resolves to its framework
+		{name: "go-chii", want: "chi", wantOK: true},
+		{name: "django", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := closestFramework(tt.name)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("closestFramework(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestResolveFramework(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr string
+	}{
+		{value: "chi", want: "chi"},
+		{value: " Echo ", want: "echo"},
+		{value: "net/http", want: "stdlib"},
+		{value: "GoFiber", want: "fiber"},
+		{value: "gni", wantErr: `did you mean "gin"?`},
+		{value: "django", wantErr: "expected one of stdlib, gin, echo, fiber, chi"},
+	}
+
+	for _, tt := range tests {
+		got, err := resolveFramework(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveFramework(%q) error = %v, want it to contain %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolveFramework(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
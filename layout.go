@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// projectLayout is what a --layout decides: the directories created up front
+// and where the generated internal packages go.
+type projectLayout struct {
+	dirs     []string          // relative to the project root
+	packages map[string]string // package name to its directory
+}
+
+// flatPackages keeps every generated package one level below internal/.
+var flatPackages = map[string]string{
+	"handlers":   "internal/handlers",
+	"repository": "internal/repository",
+	"graph":      "internal/graph",
+	"services":   "internal/services",
+	"domain":     "internal/domain",
+	"ports":      "internal/ports",
+	"events":     "internal/events",
+}
+
+// layouts maps each --layout to its directories and package locations.
+// Directories still empty once the files are written get a .gitkeep, so git
+// tracks the whole scaffold.
+var layouts = map[string]projectLayout{
+	"hexagonal": {
+		dirs: []string{
+			"internal/adapters",
+			"internal/config",
+			"internal/core",
+			"internal/adapters/handlers",
+			"internal/adapters/repository",
+			"internal/core/domain",
+			"internal/core/ports",
+			"internal/core/services",
+		},
+		packages: map[string]string{
+			"handlers":   "internal/adapters/handlers",
+			"repository": "internal/adapters/repository",
+			"graph":      "internal/adapters/graph",
+			"services":   "internal/core/services",
+			"domain":     "internal/core/domain",
+			"ports":      "internal/core/ports",
+			"events":     "internal/core/events",
+		},
+	},
+	"flat": {
+		dirs:     []string{"cmd", "internal"},
+		packages: flatPackages,
+	},
+	"standard": {
+		dirs:     []string{"cmd", "internal", "pkg", "api", "configs", "scripts"},
+		packages: flatPackages,
+	},
+}
+
+// layout returns the project's layout, hexagonal when none is set.
+func (c ProjectConfig) layout() projectLayout {
+	if l, ok := layouts[c.Layout]; ok {
+		return l
+	}
+	return layouts["hexagonal"]
+}
+
+// PackageDir is the directory of a generated internal package, such as
+// "handlers", relative to the project root.
+func (c ProjectConfig) PackageDir(name string) string {
+	return c.layout().packages[name]
+}
+
+// PackagePath is the import path of the generated package name.
+func (c ProjectConfig) PackagePath(name string) string {
+	return c.ModulePath() + "/" + c.PackageDir(name)
+}
+
+// packageFile is the path of file in the generated package name, from the
+// directory shatkon runs in.
+func (c ProjectConfig) packageFile(name, file string) string {
+	return c.ProjectName + "/" + c.PackageDir(name) + "/" + file
+}
+
+// layoutNames lists the layouts, sorted, for flag help and errors.
+func layoutNames() []string {
+	names := make([]string, 0, len(layouts))
+	for name := range layouts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func validateLayout(layout string) error {
+	if _, ok := layouts[layout]; !ok {
+		return fmt.Errorf("unknown layout %q, expected one of %s", layout, strings.Join(layoutNames(), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+// TestGeneratedProjectsBuild generates projects for combinations that once
+// produced code that parses but doesn't compile, and runs go build on them.
+// It downloads the projects' modules, so -short skips it.
+func TestGeneratedProjectsBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("downloads modules")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+	}{
+		{
+			name: "echo-main-wiring-profile",
+			cfg:  ProjectConfig{Framework: "echo", Database: "none", DI: "none", Pprof: true},
+		},
+		{
+			// Both services build their server in package app.
+			name: "app-services-profile",
+			cfg: ProjectConfig{
+				Framework: "chi",
+				Database:  "none",
+				DI:        "manual",
+				Pprof:     true,
+				SSE:       true,
+				Services:  []Service{{Name: "api", Framework: "chi"}, {Name: "web-ui", Framework: "stdlib"}},
+			},
+		},
+		{
+			name: "redis-sessions-app",
+			cfg:  ProjectConfig{Framework: "gin", Database: "redis", DI: "manual", Sessions: "redis"},
+		},
+		{
+			name: "redis-sessions-main",
+			cfg:  ProjectConfig{Framework: "stdlib", Database: "redis", DI: "none", Sessions: "redis"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.GithubUserID = "user"
+			cfg.ProjectName = tt.name
+			cfg.Layout = "hexagonal"
+			cfg.Port = 8080
+			cfg.APIClient = "none"
+			if cfg.Sessions == "" {
+				cfg.Sessions = "none"
+			}
+
+			if err := InitProject(cfg, &phaseTimer{}); err != nil {
+				t.Fatal(err)
+			}
+			if err := writeProjectFiles(cfg); err != nil {
+				t.Fatal(err)
+			}
+			if err := formatGoFiles(cfg); err != nil {
+				t.Fatal(err)
+			}
+			if err := goModTidy(cfg); err != nil {
+				t.Fatal(err)
+			}
+			if err := goBuild(cfg); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
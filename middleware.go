@@ -0,0 +1,127 @@
+package main
+
+import "slices"
+
+// middlewareOrder is the order middleware are registered in, outermost
+// first, whatever order they were picked in: recovery wraps everything so it
+// catches panics from the rest of the chain, request IDs are assigned before
+// anything logs, and logging sees requests the body limit rejects.
+var middlewareOrder = []string{"recovery", "request-id", "logging", "body-limit"}
+
+// MiddlewareUse is a middleware registration line in the generated main.go.
+type MiddlewareUse struct {
+	Name   string
+	Use    string
+	Import string
+}
+
+// middlewareUses maps each framework's middleware to the code registering it.
+// Stdlib middleware wrap the mux rather than being registered on it, and
+// fiber's body limit is part of fiber.Config instead of a middleware.
+var middlewareUses = map[string]map[string]MiddlewareUse{
+	"stdlib": {
+		"recovery":   {Use: "handler = middleware.Recover(handler)"},
+		"request-id": {Use: "handler = middleware.RequestID(handler)"},
+		"logging":    {Use: "handler = middleware.Logger(handler)"},
+		"body-limit": {Use: "handler = middleware.BodyLimit(cfg.BodyLimit)(handler)"},
+	},
+	"echo": {
+		"recovery":   {Use: "e.Use(middleware.Recover())", Import: "github.com/labstack/echo/v4/middleware"},
+		"request-id": {Use: "e.Use(middleware.RequestID())", Import: "github.com/labstack/echo/v4/middleware"},
+		"logging":    {Use: "e.Use(utils.CustomLogger())"},
+		"body-limit": {Use: "e.Use(middleware.BodyLimit(strconv.FormatInt(cfg.BodyLimit, 10)))", Import: "github.com/labstack/echo/v4/middleware"},
+	},
+	"gin": {
+		"recovery":   {Use: "r.Use(gin.Recovery())"},
+		"request-id": {Use: "r.Use(requestid.New())", Import: "github.com/gin-contrib/requestid"},
+		"logging":    {Use: "r.Use(gin.Logger())"},
+		"body-limit": {Use: "r.Use(func(c *gin.Context) {\n\t\tc.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, cfg.BodyLimit)\n\t\tc.Next()\n\t})"},
+	},
+	"chi": {
+		"recovery":   {Use: "r.Use(middleware.Recoverer)", Import: "github.com/go-chi/chi/v5/middleware"},
+		"request-id": {Use: "r.Use(middleware.RequestID)", Import: "github.com/go-chi/chi/v5/middleware"},
+		"logging":    {Use: "r.Use(middleware.Logger)", Import: "github.com/go-chi/chi/v5/middleware"},
+		"body-limit": {Use: "r.Use(middleware.RequestSize(cfg.BodyLimit))", Import: "github.com/go-chi/chi/v5/middleware"},
+	},
+	"fiber": {
+		"recovery":   {Use: "app.Use(recover.New())", Import: "github.com/gofiber/fiber/v2/middleware/recover"},
+		"request-id": {Use: "app.Use(requestid.New())", Import: "github.com/gofiber/fiber/v2/middleware/requestid"},
+		"logging":    {Use: "app.Use(logger.New())", Import: "github.com/gofiber/fiber/v2/middleware/logger"},
+	},
+}
+
+// structuredLogUses registers the pkg/logging request logger, replacing the
+// framework's own logger when the structured log format is picked.
+var structuredLogUses = map[string]MiddlewareUse{
+	"stdlib": {Use: "handler = logging.HTTP(handler)"},
+	"echo":   {Use: "e.Use(logging.Echo())"},
+	"gin":    {Use: "r.Use(logging.Gin())"},
+	"chi":    {Use: "r.Use(logging.Chi)"},
+	"fiber":  {Use: "app.Use(logging.Fiber())"},
+}
+
+// StructuredLogging reports whether requests are logged by the generated
+// pkg/logging, with the same fields on every framework.
+func (c ProjectConfig) StructuredLogging() bool {
+	return c.Logging && c.LogFormat == "structured"
+}
+
+// enabledMiddleware reports which middleware the project uses. Requests are
+// logged when logging is enabled, and gin keeps the recovery gin.Default used
+// to install.
+func (c ProjectConfig) enabledMiddleware(name string) bool {
+	switch {
+	case slices.Contains(c.Middleware, name):
+		return true
+	case name == "logging":
+		return c.Logging
+	case name == "recovery":
+		return c.Framework == "gin"
+	}
+	return false
+}
+
+// usesMiddlewarePackage reports whether a stdlib main.go wraps its mux with
+// the generated pkg/middleware, which also holds the plain request logger.
+func (c ProjectConfig) usesMiddlewarePackage() bool {
+	return len(c.Middleware) > 0 || (c.Logging && !c.StructuredLogging())
+}
+
+// HasMiddleware reports whether the generated main.go uses the named
+// middleware, for templates that need extra setup for it.
+func (c ProjectConfig) HasMiddleware(name string) bool {
+	return c.enabledMiddleware(name)
+}
+
+// MiddlewareChain lists the middleware registrations for c.Framework in
+// middlewareOrder. Stdlib middleware wrap the mux, so they are returned
+// innermost first for the outermost to be applied last.
+func (c ProjectConfig) MiddlewareChain() []MiddlewareUse {
+	var chain []MiddlewareUse
+	for _, name := range middlewareOrder {
+		use, ok := middlewareUses[c.Framework][name]
+		if name == "logging" && c.StructuredLogging() {
+			use, ok = structuredLogUses[c.Framework], true
+		}
+		if !ok || !c.enabledMiddleware(name) {
+			continue
+		}
+		use.Name = name
+		chain = append(chain, use)
+	}
+	if c.Framework == "stdlib" {
+		slices.Reverse(chain)
+	}
+	return chain
+}
+
+// MiddlewareImports lists the third-party packages MiddlewareChain needs.
+func (c ProjectConfig) MiddlewareImports() []string {
+	var imports []string
+	for _, use := range c.MiddlewareChain() {
+		if use.Import != "" && !slices.Contains(imports, use.Import) {
+			imports = append(imports, use.Import)
+		}
+	}
+	return imports
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	all := []string{"body-limit", "logging", "request-id", "recovery"}
+
+	tests := []struct {
+		name string
+		cfg  ProjectConfig
+		want []string
+	}{
+		{
+			name: "picked order is ignored",
+			cfg:  ProjectConfig{Framework: "echo", Middleware: all},
+			want: []string{"recovery", "request-id", "logging", "body-limit"},
+		},
+		{
+			name: "stdlib wraps innermost first",
+			cfg:  ProjectConfig{Framework: "stdlib", Middleware: all},
+			want: []string{"body-limit", "logging", "request-id", "recovery"},
+		},
+		{
+			name: "gin keeps the gin.Default recovery",
+			cfg:  ProjectConfig{Framework: "gin"},
+			want: []string{"recovery"},
+		},
+		{
+			name: "gin logs only with logging enabled",
+			cfg:  ProjectConfig{Framework: "gin", Logging: true},
+			want: []string{"recovery", "logging"},
+		},
+		{
+			name: "chi logs only with logging enabled",
+			cfg:  ProjectConfig{Framework: "chi", Middleware: []string{"request-id"}},
+			want: []string{"request-id"},
+		},
+		{
+			name: "fiber body limit lives in fiber.Config",
+			cfg:  ProjectConfig{Framework: "fiber", Middleware: []string{"body-limit", "recovery"}},
+			want: []string{"recovery"},
+		},
+		{
+			name: "logging option enables the logger",
+			cfg:  ProjectConfig{Framework: "stdlib", Logging: true, Middleware: []string{"recovery"}},
+			want: []string{"logging", "recovery"},
+		},
+		{
+			name: "none",
+			cfg:  ProjectConfig{Framework: "echo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, use := range tt.cfg.MiddlewareChain() {
+				got = append(got, use.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MiddlewareChain() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareChainStructuredLogging(t *testing.T) {
+	cfg := ProjectConfig{Framework: "chi", Logging: true, LogFormat: "structured"}
+	chain := cfg.MiddlewareChain()
+	if len(chain) != 1 || chain[0].Use != structuredLogUses["chi"].Use {
+		t.Errorf("MiddlewareChain() = %+v, want only %q", chain, structuredLogUses["chi"].Use)
+	}
+}
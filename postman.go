@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type postmanCollection struct {
+	Info     postmanInfo       `json:"info"`
+	Variable []postmanVariable `json:"variable"`
+	Item     []postmanItem     `json:"item"`
+}
+
+type postmanInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+type postmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanItem struct {
+	Name    string         `json:"name"`
+	Request postmanRequest `json:"request"`
+}
+
+type postmanRequest struct {
+	Method string          `json:"method"`
+	Header []postmanHeader `json:"header"`
+	URL    postmanURL      `json:"url"`
+	Body   *postmanBody    `json:"body,omitempty"`
+}
+
+type postmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type postmanURL struct {
+	Raw  string   `json:"raw"`
+	Host []string `json:"host"`
+	Path []string `json:"path"`
+}
+
+type postmanBody struct {
+	Mode    string             `json:"mode"`
+	Raw     string             `json:"raw"`
+	Options postmanBodyOptions `json:"options"`
+}
+
+type postmanBodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+func addPostmanCollection(cfg ProjectConfig) error {
+	content, err := postmanCollectionJSON(cfg)
+	if err != nil {
+		return err
+	}
+	return CreateFile(content, cfg.ProjectName+"/postman_collection.json")
+}
+
+// postmanCollectionJSON describes the generated routes as a Postman v2.1
+// collection. Requests are relative to a {{baseUrl}} collection variable.
+func postmanCollectionJSON(cfg ProjectConfig) (string, error) {
+	collection := postmanCollection{
+		Info: postmanInfo{Name: cfg.ProjectName, Schema: postmanSchema},
+		Variable: []postmanVariable{
+			{Key: "baseUrl", Value: cfg.BaseURL()},
+		},
+		Item: []postmanItem{},
+	}
+
+	for _, route := range cfg.APIRoutes() {
+		req := postmanRequest{
+			Method: route.RequestMethod(),
+			Header: []postmanHeader{},
+			URL: postmanURL{
+				Raw:  "{{baseUrl}}" + route.Path,
+				Host: []string{"{{baseUrl}}"},
+				Path: strings.Split(strings.TrimPrefix(route.Path, "/"), "/"),
+			},
+		}
+
+		if route.Body != "" {
+			body := &postmanBody{Mode: "raw", Raw: route.Body}
+			body.Options.Raw.Language = "json"
+			req.Body = body
+			req.Header = append(req.Header, postmanHeader{Key: "Content-Type", Value: "application/json"})
+		}
+
+		collection.Item = append(collection.Item, postmanItem{Name: route.Name, Request: req})
+	}
+
+	out, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	nameFlag       = flag.String("name", "", "project name; with --github-user, --framework and --database the form is skipped")
+	githubUserFlag = flag.String("github-user", "", "GitHub user ID the module path is built from")
+	frameworkFlag  = flag.String("framework", "", "web framework: "+strings.Join(frameworks, ", "))
+	databaseFlag   = flag.String("database", "", "database: "+strings.Join(databases, ", "))
+	loggingFlag    = flag.Bool("logging", false, "add logging middleware")
+)
+
+var (
+	projectNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+	goVersionRegex   = regexp.MustCompile(`^1\.([0-9]+)(\.[0-9]+)?$`)
+	databases        = []string{"postgresql", "mongodb", "mysql", "sqlite", "redis", "none"}
+	databaseAliases  = map[string]string{"postgres": "postgresql", "pg": "postgresql", "mongo": "mongodb", "mariadb": "mysql"}
+)
+
+// applyProjectFlags copies the project flags into config and reports whether
+// they answer every question the form requires, so it can be skipped. The
+// form's other questions then keep their defaults.
+func applyProjectFlags(config *ProjectConfig) (bool, error) {
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	config.ProjectName = strings.TrimSpace(*nameFlag)
+	if set["name"] {
+		if err := validateProjectName(config.ProjectName); err != nil {
+			return false, err
+		}
+	}
+	config.GithubUserID = strings.TrimSpace(*githubUserFlag)
+	if set["github-user"] && config.GithubUserID == "" {
+		return false, errors.New("--github-user cannot be empty")
+	}
+
+	if set["framework"] {
+		framework, err := resolveFramework(*frameworkFlag)
+		if err != nil {
+			return false, err
+		}
+		config.Framework = framework
+	}
+	if set["database"] {
+		database, err := resolveDatabase(*databaseFlag)
+		if err != nil {
+			return false, err
+		}
+		config.Database = database
+	}
+	config.Logging = *loggingFlag
+
+	complete := config.ProjectName != "" && config.GithubUserID != "" && config.Framework != "" && config.Database != ""
+	if !complete {
+		return false, nil
+	}
+
+	config.APIClient = "none"
+	config.Sessions = "none"
+	if config.SQLDatabase() {
+		config.ORM = "gorm"
+	}
+	if config.Logging {
+		config.LogFormat = "structured"
+	}
+	return true, nil
+}
+
+// resolveDatabase returns the database for a --database value, accepting a
+// few common aliases.
+func resolveDatabase(value string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(value))
+	if canonical, ok := databaseAliases[name]; ok {
+		name = canonical
+	}
+	if !slices.Contains(databases, name) {
+		return "", fmt.Errorf("unknown database %q, expected one of %s", value, strings.Join(databases, ", "))
+	}
+	return name, nil
+}
+
+// validateProjectName makes sure name works both as the project directory
+// and as the last element of the module path.
+func validateProjectName(name string) error {
+	if name == "" {
+		return errors.New("project name cannot be empty")
+	}
+	if !projectNameRegex.MatchString(name) {
+		return errors.New("project name may only contain letters, digits, hyphens and underscores, and must start with a letter or digit")
+	}
+	return nil
+}
+
+// minGoMinor is the oldest Go 1.x the templates build with: they register
+// routes with Go 1.22 method patterns such as "GET /livez", which older go
+// directives turn back into plain paths.
+const minGoMinor = 22
+
+// parseGoVersion checks a --go-version value looks like a Go release the
+// templates support, such as 1.22 or 1.22.3. The go directive compares 1.22
+// as older than the 1.22.0 release dependencies declare, so a version
+// without a patch gets a ".0". An empty value keeps the toolchain's version.
+func parseGoVersion(version string) (string, error) {
+	if version == "" {
+		return "", nil
+	}
+	m := goVersionRegex.FindStringSubmatch(version)
+	if m == nil {
+		return "", fmt.Errorf("invalid --go-version %q, expected a version like 1.22 or 1.22.3", version)
+	}
+	if minor, _ := strconv.Atoi(m[1]); minor < minGoMinor {
+		return "", fmt.Errorf("--go-version %s is too old, the generated code needs Go 1.%d or newer", version, minGoMinor)
+	}
+	if m[2] == "" {
+		return version + ".0", nil
+	}
+	return version, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestParseGoVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+		wantErr bool
+	}{
+		{version: "", want: ""},
+		{version: "1.22", want: "1.22.0"},
+		{version: "1.22.3", want: "1.22.3"},
+		{version: "1.25", want: "1.25.0"},
+		{version: "1.21", wantErr: true},
+		{version: "1.21.5", wantErr: true},
+		{version: "1.9", wantErr: true},
+		{version: "2.0", wantErr: true},
+		{version: "go1.22", wantErr: true},
+		{version: "1.22.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseGoVersion(tt.version)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseGoVersion(%q) error = %v, want error %v", tt.version, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseGoVersion(%q) = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// GenerationResult records the directories and files a run creates, for the
+// summary and the other reports. It is safe for concurrent use.
+type GenerationResult struct {
+	mu      sync.Mutex
+	dirs    []string
+	files   []string
+	sources map[string]string // file path to the template it's rendered from
+	imports []string          // import paths of the generated Go files
+}
+
+// generated collects everything this run creates. CreateFile and InitProject
+// add to it as they go.
+var generated = &GenerationResult{}
+
+func (r *GenerationResult) AddDir(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !slices.Contains(r.dirs, path) {
+		r.dirs = append(r.dirs, path)
+	}
+}
+
+func (r *GenerationResult) AddFile(path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !slices.Contains(r.files, path) {
+		r.files = append(r.files, path)
+	}
+}
+
+// AddImports records the import paths of a generated Go file.
+func (r *GenerationResult) AddImports(paths ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, path := range paths {
+		if !slices.Contains(r.imports, path) {
+			r.imports = append(r.imports, path)
+		}
+	}
+}
+
+// Imports returns the import paths of every generated Go file, sorted.
+func (r *GenerationResult) Imports() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	imports := slices.Clone(r.imports)
+	slices.Sort(imports)
+	return imports
+}
+
+// SetSource records the template, or tool, a file is generated from.
+func (r *GenerationResult) SetSource(path, source string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sources == nil {
+		r.sources = make(map[string]string)
+	}
+	r.sources[filepath.ToSlash(path)] = source
+}
+
+// Source returns what SetSource recorded for path, if anything.
+func (r *GenerationResult) Source(path string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sources[filepath.ToSlash(path)]
+}
+
+// AddFileIfExists records a file written by an external tool, such as go.sum
+// after go mod tidy.
+func (r *GenerationResult) AddFileIfExists(path string) {
+	if _, err := os.Stat(path); err == nil {
+		r.AddFile(path)
+	}
+}
+
+// HasDir reports whether this run created the directory at path.
+func (r *GenerationResult) HasDir(path string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Contains(r.dirs, path)
+}
+
+// Dirs returns the created directories relative to root, sorted.
+func (r *GenerationResult) Dirs(root string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return relativeTo(root, r.dirs)
+}
+
+// Files returns the created files relative to root, sorted.
+func (r *GenerationResult) Files(root string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return relativeTo(root, r.files)
+}
+
+func relativeTo(root string, paths []string) []string {
+	rel := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if p, err := filepath.Rel(root, path); err == nil {
+			path = p
+		}
+		rel = append(rel, filepath.ToSlash(path))
+	}
+	slices.Sort(rel)
+	return rel
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Service is one entrypoint of a multi-service project, generated as
+// cmd/<Name>/main.go. All services share the project's internal packages and
+// go.mod.
+type Service struct {
+	Name      string
+	Framework string
+}
+
+var (
+	frameworks       = []string{"stdlib", "gin", "echo", "fiber", "chi"}
+	serviceNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+)
+
+// parseServices parses the --services flag, e.g. "api:echo,worker". Services
+// without a framework are left empty and use the project's framework.
+func parseServices(value string) ([]Service, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var services []Service
+	seen := make(map[string]bool)
+	for _, spec := range strings.Split(value, ",") {
+		name, framework, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		if !serviceNameRegex.MatchString(name) {
+			return nil, fmt.Errorf("invalid service name %q: use lowercase letters, digits, hyphens and underscores", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("service %q is listed more than once", name)
+		}
+		if framework != "" {
+			var err error
+			if framework, err = resolveFramework(framework); err != nil {
+				return nil, fmt.Errorf("service %s: %w", name, err)
+			}
+		}
+		seen[name] = true
+		services = append(services, Service{Name: name, Framework: framework})
+	}
+	return services, nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseServices(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []Service
+		wantErr string
+	}{
+		{value: "", want: nil},
+		{value: "  ", want: nil},
+		{value: "api", want: []Service{{Name: "api"}}},
+		{value: "api:echo, worker", want: []Service{{Name: "api", Framework: "echo"}, {Name: "worker"}}},
+		{value: "web:GoFiber", want: []Service{{Name: "web", Framework: "fiber"}}},
+		{value: "api,api", wantErr: `service "api" is listed more than once`},
+		{value: "Api", wantErr: `invalid service name "Api"`},
+		{value: "-api", wantErr: `invalid service name "-api"`},
+		{value: "api,", wantErr: `invalid service name ""`},
+		{value: "api:gni", wantErr: `service api: unknown framework "gni", did you mean "gin"?`},
+	}
+
+	for _, tt := range tests {
+		got, err := parseServices(tt.value)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseServices(%q) error = %v, want it to contain %q", tt.value, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("parseServices(%q) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// templatesFS holds the built-in templates, one file per template.
+//
+//go:embed templates/*
+var templatesFS embed.FS
+
+// Templates are named by their path under templates/, which is also the path
+// "shatkon templates export" writes them to.
+const (
+	stdLibTemplate                  = "main/stdlib.go.tmpl"
+	ginTemplate                     = "main/gin.go.tmpl"
+	echoTemplate                    = "main/echo.go.tmpl"
+	fiberTemplate                   = "main/fiber.go.tmpl"
+	chiTemplate                     = "main/chi.go.tmpl"
+	cfgTemplate                     = "config/config.go.tmpl"
+	envTemplate                     = "config/env.tmpl"
+	envExampleTemplate              = "config/env.example.tmpl"
+	gitignoreTemplate               = "gitignore.tmpl"
+	loggerTemplate                  = "utils/logger.go.tmpl"
+	structuredLoggerTemplate        = "logging/logging.go.tmpl"
+	httpLoggerTemplate              = "logging/http.go.tmpl"
+	chiLoggerTemplate               = "logging/chi.go.tmpl"
+	echoLoggerTemplate              = "logging/echo.go.tmpl"
+	ginLoggerTemplate               = "logging/gin.go.tmpl"
+	fiberLoggerTemplate             = "logging/fiber.go.tmpl"
+	healthTemplate                  = "handlers/health.go.tmpl"
+	healthTestTemplate              = "handlers/handler_test.go.tmpl"
+	sessionHandlersTemplate         = "handlers/session.go.tmpl"
+	eventsTemplate                  = "handlers/events.go.tmpl"
+	fiberEventsTemplate             = "handlers/events_fiber.go.tmpl"
+	recoverTemplate                 = "handlers/recover.go.tmpl"
+	recoverTestTemplate             = "handlers/recover_test.go.tmpl"
+	rootTemplate                    = "handlers/root.go.tmpl"
+	rootTestTemplate                = "handlers/root_test.go.tmpl"
+	chiRootTestTemplate             = "handlers/root_chi_test.go.tmpl"
+	ginRootTemplate                 = "handlers/root_gin.go.tmpl"
+	ginRootTestTemplate             = "handlers/root_gin_test.go.tmpl"
+	echoRootTemplate                = "handlers/root_echo.go.tmpl"
+	echoRootTestTemplate            = "handlers/root_echo_test.go.tmpl"
+	fiberRootTemplate               = "handlers/root_fiber.go.tmpl"
+	fiberRootTestTemplate           = "handlers/root_fiber_test.go.tmpl"
+	betaHandlerTemplate             = "handlers/beta.go.tmpl"
+	pprofTemplate                   = "handlers/pprof.go.tmpl"
+	serverTemplate                  = "server/server.go.tmpl"
+	adminServerTemplate             = "admin/admin.go.tmpl"
+	sqliteTemplate                  = "db/sqlite.go.tmpl"
+	pgSqlTemplate                   = "db/postgresql.go.tmpl"
+	mongoDBTemplate                 = "db/mongodb.go.tmpl"
+	mysqlTemplate                   = "db/mysql.go.tmpl"
+	redisTemplate                   = "db/redis.go.tmpl"
+	bunStoreTemplate                = "db/bun.go.tmpl"
+	bunUserRepositoryTemplate       = "db/bun_user.go.tmpl"
+	bunMigrationsTemplate           = "db/bun_migrations.go.tmpl"
+	bunCreateUsersMigrationTemplate = "db/bun_create_users.go.tmpl"
+	gqlgenConfigTemplate            = "graphql/gqlgen.yml.tmpl"
+	gqlgenToolsTemplate             = "graphql/tools.go.tmpl"
+	graphqlSchemaTemplate           = "graphql/schema.graphqls.tmpl"
+	graphqlResolverTemplate         = "graphql/resolver.go.tmpl"
+	graphqlSchemaResolversTemplate  = "graphql/schema.resolvers.go.tmpl"
+	graphqlHandlerTemplate          = "graphql/handler.go.tmpl"
+	appTemplate                     = "app/app.go.tmpl"
+	appMainTemplate                 = "app/main.go.tmpl"
+	servicesTemplate                = "core/service.go.tmpl"
+	eventBusTemplate                = "core/events_bus.go.tmpl"
+	domainEventsTemplate            = "core/domain_user.go.tmpl"
+	eventPortsTemplate              = "core/ports_events.go.tmpl"
+	userServiceTemplate             = "core/user_service.go.tmpl"
+	httpClientTemplate              = "pkg/client.go.tmpl"
+	sessionTemplate                 = "pkg/session.go.tmpl"
+	stdlibMiddlewareTemplate        = "pkg/middleware.go.tmpl"
+	featureFlagsTemplate            = "pkg/flags.go.tmpl"
+	openAPISpecTemplate             = "api/openapi.yaml.tmpl"
+	openAPIDocsTemplate             = "api/docs.go.tmpl"
+	securityTemplate                = "community/SECURITY.md.tmpl"
+	codeOfConductTemplate           = "community/CODE_OF_CONDUCT.md.tmpl"
+	changelogTemplate               = "community/CHANGELOG.md.tmpl"
+	githubSettingsTemplate          = "community/settings.yml.tmpl"
+	makefileTemplate                = "Makefile.tmpl"
+	dockerfileTemplate              = "docker/Dockerfile.tmpl"
+	dockerignoreTemplate            = "docker/dockerignore.tmpl"
+	dockerComposeTemplate           = "docker/docker-compose.yml.tmpl"
+)
+
+// customTemplates maps template names to the versions loaded from the
+// --templates directory.
+var customTemplates map[string]string
+
+// templateNames lists every built-in template.
+func templateNames() ([]string, error) {
+	var names []string
+	err := fs.WalkDir(templatesFS, "templates", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		names = append(names, p[len("templates/"):])
+		return nil
+	})
+	return names, err
+}
+
+// loadCustomTemplates reads the templates in dir that replace built-in ones.
+// Files are matched by their template name, and templates missing from dir
+// keep the built-in version. Unknown .tmpl files are an error, so a
+// misspelled name doesn't go unnoticed.
+func loadCustomTemplates(dir string) error {
+	custom := make(map[string]string)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(p) != ".tmpl" {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if _, err := fs.Stat(templatesFS, path.Join("templates", name)); err != nil {
+			return fmt.Errorf("unknown template %s, see \"shatkon templates export\" for the names", p)
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		custom[name] = string(data)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load templates: %w", err)
+	}
+
+	customTemplates = custom
+	return nil
+}
+
+// templateContent returns the named template, or its --templates
+// replacement.
+func templateContent(name string) (string, error) {
+	if custom, ok := customTemplates[name]; ok {
+		return custom, nil
+	}
+	data, err := templatesFS.ReadFile(path.Join("templates", name))
+	if err != nil {
+		return "", fmt.Errorf("unknown template %s: %w", name, err)
+	}
+	return string(data), nil
+}
+
+// templateSource describes the template a file is rendered from, for
+// --dry-run.
+func templateSource(name string) string {
+	if _, ok := customTemplates[name]; ok {
+		return name + " (custom)"
+	}
+	return name
+}
+
+// runTemplates handles "shatkon templates <command>".
+func runTemplates(args []string) error {
+	if len(args) != 2 || args[0] != "export" {
+		return errors.New("usage: shatkon templates export <dir>")
+	}
+	return exportTemplates(args[1])
+}
+
+// exportTemplates writes every built-in template under dir, for editing. It
+// refuses to overwrite templates already there.
+func exportTemplates(dir string) error {
+	names, err := templateNames()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(p); err == nil {
+			return fmt.Errorf("%s already exists", p)
+		}
+	}
+
+	for _, name := range names {
+		data, err := templatesFS.ReadFile(path.Join("templates", name))
+		if err != nil {
+			return err
+		}
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Exported %d templates to %s\n", len(names), dir)
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+	"text/template"
+)
+
+// TestFrameworkTemplatesParse renders every framework's main.go template
+// with a bare and a fully loaded configuration and checks the result is
+// syntactically valid Go.
+func TestFrameworkTemplatesParse(t *testing.T) {
+	mainTemplates := map[string]string{
+		"stdlib": stdLibTemplate,
+		"gin":    ginTemplate,
+		"echo":   echoTemplate,
+		"fiber":  fiberTemplate,
+		"chi":    chiTemplate,
+	}
+
+	configs := map[string]ProjectConfig{
+		"bare": {Database: "none", DI: "none"},
+		"full": {
+			Database:     "postgresql",
+			ORM:          "gorm",
+			Logging:      true,
+			LogFormat:    "structured",
+			GraphQL:      true,
+			Sessions:     "cookie",
+			SSE:          true,
+			FeatureFlags: true,
+			DI:           "manual",
+			Middleware:   []string{"recovery", "request-id", "body-limit"},
+			OpenAPI:      true,
+			Pprof:        true,
+		},
+	}
+
+	for framework, tmplName := range mainTemplates {
+		tmpl, err := templateContent(tmplName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, cfg := range configs {
+			cfg.GithubUserID = "user"
+			cfg.ProjectName = "project"
+			cfg.Framework = framework
+			cfg.Port = 8080
+
+			t.Run(framework+"/"+name, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := template.Must(template.New(framework).Parse(tmpl)).Execute(&buf, cfg); err != nil {
+					t.Fatalf("render: %v", err)
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), "main.go", buf.Bytes(), parser.AllErrors); err != nil {
+					t.Fatalf("generated main.go does not parse: %v\n%s", err, buf.String())
+				}
+			})
+		}
+	}
+}
+
+// TestTemplatesEmbedded checks every template shatkon renders resolves from
+// the embedded templates directory, and that no file there goes unused.
+func TestTemplatesEmbedded(t *testing.T) {
+	expected := []string{
+		stdLibTemplate,
+		ginTemplate,
+		echoTemplate,
+		fiberTemplate,
+		chiTemplate,
+		cfgTemplate,
+		envTemplate,
+		envExampleTemplate,
+		gitignoreTemplate,
+		loggerTemplate,
+		structuredLoggerTemplate,
+		httpLoggerTemplate,
+		chiLoggerTemplate,
+		echoLoggerTemplate,
+		ginLoggerTemplate,
+		fiberLoggerTemplate,
+		healthTemplate,
+		healthTestTemplate,
+		sessionHandlersTemplate,
+		eventsTemplate,
+		fiberEventsTemplate,
+		recoverTemplate,
+		recoverTestTemplate,
+		rootTemplate,
+		rootTestTemplate,
+		chiRootTestTemplate,
+		ginRootTemplate,
+		ginRootTestTemplate,
+		echoRootTemplate,
+		echoRootTestTemplate,
+		fiberRootTemplate,
+		fiberRootTestTemplate,
+		betaHandlerTemplate,
+		pprofTemplate,
+		serverTemplate,
+		adminServerTemplate,
+		sqliteTemplate,
+		pgSqlTemplate,
+		mongoDBTemplate,
+		mysqlTemplate,
+		redisTemplate,
+		bunStoreTemplate,
+		bunUserRepositoryTemplate,
+		bunMigrationsTemplate,
+		bunCreateUsersMigrationTemplate,
+		gqlgenConfigTemplate,
+		gqlgenToolsTemplate,
+		graphqlSchemaTemplate,
+		graphqlResolverTemplate,
+		graphqlSchemaResolversTemplate,
+		graphqlHandlerTemplate,
+		appTemplate,
+		appMainTemplate,
+		servicesTemplate,
+		eventBusTemplate,
+		domainEventsTemplate,
+		eventPortsTemplate,
+		userServiceTemplate,
+		httpClientTemplate,
+		sessionTemplate,
+		stdlibMiddlewareTemplate,
+		featureFlagsTemplate,
+		openAPISpecTemplate,
+		openAPIDocsTemplate,
+		securityTemplate,
+		codeOfConductTemplate,
+		changelogTemplate,
+		githubSettingsTemplate,
+		makefileTemplate,
+		dockerfileTemplate,
+		dockerignoreTemplate,
+		dockerComposeTemplate,
+	}
+
+	for _, name := range expected {
+		if _, err := templateContent(name); err != nil {
+			t.Error(err)
+		}
+	}
+
+	names, err := templateNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != len(expected) {
+		t.Errorf("templates/ holds %d templates, want %d", len(names), len(expected))
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type phaseTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// phaseTimer records how long each generation phase takes. Phases that run
+// more than once are accumulated under the same name.
+type phaseTimer struct {
+	phases []phaseTiming
+}
+
+func (t *phaseTimer) track(name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	for i := range t.phases {
+		if t.phases[i].Name == name {
+			t.phases[i].Duration += elapsed
+			return err
+		}
+	}
+	t.phases = append(t.phases, phaseTiming{Name: name, Duration: elapsed})
+	return err
+}
+
+func printTimings(timer *phaseTimer) {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("5"))
+	keyword := func(s string) string {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Render(s)
+	}
+
+	fmt.Fprintf(&sb, "%s\n", titleStyle.Render("Generation Timings"))
+
+	var total time.Duration
+	for _, phase := range timer.phases {
+		fmt.Fprintf(&sb, "\n%-20s %s", phase.Name+":", keyword(phase.Duration.Round(time.Millisecond).String()))
+		total += phase.Duration
+	}
+	fmt.Fprintf(&sb, "\n\n%-20s %s", "Total:", keyword(total.Round(time.Millisecond).String()))
+
+	fmt.Println(lipgloss.NewStyle().
+		Width(60).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63")).
+		Padding(1, 2).
+		Render(sb.String()))
+}
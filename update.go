@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	modulePath       = "github.com/sarthak0714/shatkon"
+	latestReleaseURL = "https://api.github.com/repos/sarthak0714/shatkon/releases/latest"
+	checksumsAsset   = "checksums.txt" // sha256sum output for every release binary
+
+	// noUpdateCheckEnv disables the update notice on normal runs when set.
+	noUpdateCheckEnv    = "SHATKON_NO_UPDATE_CHECK"
+	updateCheckInterval = 24 * time.Hour
+)
+
+// version is set at release time with -ldflags "-X main.version=v1.2.3".
+var version = "dev"
+
+type release struct {
+	TagName string         `json:"tag_name"`
+	Assets  []releaseAsset `json:"assets"`
+}
+
+type releaseAsset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// currentVersion is the ldflags version, or the module version when shatkon
+// was installed with go install.
+func currentVersion() string {
+	if version != "dev" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return version
+}
+
+func fetchLatestRelease(ctx context.Context) (*release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s checking the latest release", resp.Status)
+	}
+
+	var rel release
+	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+		return nil, fmt.Errorf("failed to decode the latest release: %w", err)
+	}
+	return &rel, nil
+}
+
+// runUpdate replaces the running binary with the latest release. When
+// shatkon was installed with go install, or the release has no binary for
+// this platform, it prints the go install command instead.
+func runUpdate() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	rel, err := fetchLatestRelease(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := currentVersion()
+	if current != "dev" && !newerVersion(rel.TagName, current) {
+		fmt.Printf("shatkon %s is up to date.\n", current)
+		return nil
+	}
+
+	installCmd := "go install " + modulePath + "@" + rel.TagName
+	asset, ok := releaseBinary(rel)
+	if installedWithGo() || !ok {
+		fmt.Printf("shatkon %s is available (you have %s). Update with:\n\n  %s\n", rel.TagName, current, installCmd)
+		return nil
+	}
+
+	// Without a published checksum the download can't be verified, so it is
+	// never installed.
+	sum, err := releaseChecksum(ctx, rel, asset.Name)
+	if err != nil {
+		fmt.Printf("shatkon %s is available (you have %s), but %v. Update with:\n\n  %s\n", rel.TagName, current, err, installCmd)
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+	if err := replaceBinary(ctx, asset.DownloadURL, sum, exe); err != nil {
+		return fmt.Errorf("failed to replace %s: %w; update manually with %q", exe, err, installCmd)
+	}
+
+	fmt.Printf("Updated shatkon %s -> %s.\n", current, rel.TagName)
+	return nil
+}
+
+// releaseBinary finds the asset built for this platform, named like
+// shatkon_linux_amd64 or shatkon_windows_amd64.exe.
+func releaseBinary(rel *release) (releaseAsset, bool) {
+	name := "shatkon_" + runtime.GOOS + "_" + runtime.GOARCH
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return findAsset(rel, name)
+}
+
+func findAsset(rel *release, name string) (releaseAsset, bool) {
+	for _, asset := range rel.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return releaseAsset{}, false
+}
+
+// releaseChecksum returns the SHA-256 checksum the release publishes for the
+// named asset in its checksums.txt.
+func releaseChecksum(ctx context.Context, rel *release, name string) (string, error) {
+	asset, ok := findAsset(rel, checksumsAsset)
+	if !ok {
+		return "", fmt.Errorf("the release publishes no %s to verify the download against", checksumsAsset)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.DownloadURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", checksumsAsset, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s downloading %s", resp.Status, checksumsAsset)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", checksumsAsset, err)
+	}
+
+	sum, ok := checksumFor(string(data), name)
+	if !ok {
+		return "", fmt.Errorf("%s has no SHA-256 checksum for %s", checksumsAsset, name)
+	}
+	return sum, nil
+}
+
+// checksumFor finds name's SHA-256 checksum in the output of sha256sum, one
+// "<hex>  <name>" line per file, as GoReleaser writes it.
+func checksumFor(checksums, name string) (string, bool) {
+	for _, line := range strings.Split(checksums, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := strings.ToLower(fields[0])
+		if _, err := hex.DecodeString(sum); err != nil || len(sum) != sha256.Size*2 {
+			return "", false
+		}
+		return sum, true
+	}
+	return "", false
+}
+
+// installedWithGo reports whether the binary carries a module version, which
+// only go install of a tagged version sets.
+func installedWithGo() bool {
+	info, ok := debug.ReadBuildInfo()
+	return ok && info.Main.Version != "" && info.Main.Version != "(devel)"
+}
+
+// replaceBinary downloads url next to exe and, once its SHA-256 checksum
+// matches sum, renames it over exe, so a failed or tampered download never
+// replaces the binary.
+func replaceBinary(ctx context.Context, url, sum, exe string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s downloading %s", resp.Status, url)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(exe), ".shatkon-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), resp.Body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != sum {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", url, got, sum)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), exe)
+}
+
+// newerVersion reports whether semantic version a is newer than b. Both may
+// carry a leading "v"; pre-release and build suffixes are ignored.
+func newerVersion(a, b string) bool {
+	pa, pb := parseVersion(a), parseVersion(b)
+	for i := range pa {
+		if pa[i] != pb[i] {
+			return pa[i] > pb[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) [3]int {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	for i, field := range strings.SplitN(v, ".", 3) {
+		parts[i], _ = strconv.Atoi(field)
+	}
+	return parts
+}
+
+// updateCheck looks for a newer release in the background. It checks at most
+// once per updateCheckInterval, caching the answer in the user cache dir.
+type updateCheck struct {
+	done   chan struct{}
+	latest string
+}
+
+func startUpdateCheck() *updateCheck {
+	check := &updateCheck{done: make(chan struct{})}
+	if os.Getenv(noUpdateCheckEnv) != "" || currentVersion() == "dev" {
+		close(check.done)
+		return check
+	}
+
+	go func() {
+		defer close(check.done)
+		check.latest, _ = latestVersionCached()
+	}()
+	return check
+}
+
+// printNotice tells the user about a newer release if the check has already
+// finished. It never waits for the network.
+func (c *updateCheck) printNotice() {
+	select {
+	case <-c.done:
+	default:
+		return
+	}
+	if c.latest != "" && newerVersion(c.latest, currentVersion()) {
+		fmt.Printf("shatkon %s is available (you have %s), run \"shatkon update\". Set %s=1 to silence this.\n",
+			c.latest, currentVersion(), noUpdateCheckEnv)
+	}
+}
+
+type updateCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest"`
+}
+
+func latestVersionCached() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	cachePath := filepath.Join(dir, "shatkon", "update-check.json")
+
+	var cache updateCache
+	if data, err := os.ReadFile(cachePath); err == nil && json.Unmarshal(data, &cache) == nil {
+		if time.Since(cache.CheckedAt) < updateCheckInterval {
+			return cache.Latest, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	rel, err := fetchLatestRelease(ctx)
+	if err != nil {
+		return "", err
+	}
+	if rel.TagName == "" {
+		return "", errors.New("latest release has no tag")
+	}
+
+	cache = updateCache{CheckedAt: time.Now(), Latest: rel.TagName}
+	if data, err := json.Marshal(cache); err == nil {
+		if err := os.MkdirAll(filepath.Dir(cachePath), os.ModePerm); err == nil {
+			os.WriteFile(cachePath, data, 0o644)
+		}
+	}
+	return rel.TagName, nil
+}
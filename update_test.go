@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewerVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.2.0", "v1.1.9", true},
+		{"v1.10.0", "v1.9.0", true}, // numeric, not lexical
+		{"v2.0.0", "v1.99.99", true},
+		{"1.2.1", "v1.2.0", true}, // the "v" is optional
+		{"v1.2.0", "v1.2.0", false},
+		{"v1.1.9", "v1.2.0", false},
+		{"v1.2.0-rc.1", "v1.2.0", false}, // pre-releases are ignored
+		{"v1.2.0+build.5", "v1.1.0", true},
+		{"v1.3", "v1.2.9", true}, // missing fields count as zero
+	}
+
+	for _, tt := range tests {
+		if got := newerVersion(tt.a, tt.b); got != tt.want {
+			t.Errorf("newerVersion(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestChecksumFor(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+	checksums := sum + "  shatkon_linux_amd64\n" +
+		strings.Repeat("cd", sha256.Size) + " *shatkon_windows_amd64.exe\n" +
+		"not-hex  shatkon_darwin_arm64\n"
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "shatkon_linux_amd64", want: sum, wantOK: true},
+		{name: "shatkon_windows_amd64.exe", want: strings.Repeat("cd", sha256.Size), wantOK: true}, // binary mode marker
+		{name: "shatkon_darwin_arm64", wantOK: false},                                              // malformed checksum
+		{name: "shatkon_linux_arm64", wantOK: false},                                               // not listed
+		{name: "shatkon_linux", wantOK: false},                                                     // no prefix matches
+	}
+
+	for _, tt := range tests {
+		got, ok := checksumFor(checksums, tt.name)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("checksumFor(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestReplaceBinary(t *testing.T) {
+	binary := []byte("new shatkon")
+	h := sha256.Sum256(binary)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(binary)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		sum     string
+		want    string // exe contents afterwards
+		wantErr bool
+	}{
+		{name: "matching checksum", sum: hex.EncodeToString(h[:]), want: "new shatkon"},
+		{name: "mismatched checksum", sum: strings.Repeat("00", sha256.Size), want: "old shatkon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exe := filepath.Join(t.TempDir(), "shatkon")
+			if err := os.WriteFile(exe, []byte("old shatkon"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			err := replaceBinary(context.Background(), srv.URL, tt.sum, exe)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replaceBinary() error = %v, want error %v", err, tt.wantErr)
+			}
+			got, err := os.ReadFile(exe)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("exe = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}